@@ -0,0 +1,16 @@
+package cloud
+
+import "github.com/spf13/cobra"
+
+// NewCmdCloud is the parent "osdctl cloud" command, grouping cloud-provider
+// utilities.
+func NewCmdCloud() *cobra.Command {
+	cloudCmd := &cobra.Command{
+		Use:   "cloud",
+		Short: "Cloud provider utilities",
+	}
+
+	cloudCmd.AddCommand(newCmdCredentials())
+
+	return cloudCmd
+}
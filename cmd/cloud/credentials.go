@@ -0,0 +1,40 @@
+package cloud
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/osdctl/pkg/osdCloud"
+)
+
+// newCmdCredentials implements "osdctl cloud credentials", the parent for
+// subcommands that manage osdctl's on-disk assume-role credential cache.
+func newCmdCredentials() *cobra.Command {
+	credentialsCmd := &cobra.Command{
+		Use:   "credentials",
+		Short: "Manage cached assume-role credentials",
+	}
+
+	credentialsCmd.AddCommand(newCmdCredentialsPurge())
+
+	return credentialsCmd
+}
+
+// newCmdCredentialsPurge implements "osdctl cloud credentials purge", which
+// invalidates every credential osdctl has cached locally.
+func newCmdCredentialsPurge() *cobra.Command {
+	return &cobra.Command{
+		Use:   "purge",
+		Short: "Invalidate all cached assume-role credentials",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := osdCloud.PurgeCredentialCache(); err != nil {
+				return fmt.Errorf("failed to purge credential cache: %w", err)
+			}
+
+			fmt.Println("Credential cache purged")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package cluster
+
+import "github.com/spf13/cobra"
+
+// NewCmdCluster is the parent "osdctl cluster" command, grouping
+// cluster-scoped subcommands.
+func NewCmdCluster() *cobra.Command {
+	clusterCmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Cluster-scoped utilities",
+	}
+
+	clusterCmd.AddCommand(newCmdConsole())
+
+	return clusterCmd
+}
@@ -0,0 +1,42 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/osdctl/pkg/osdCloud"
+)
+
+// newCmdConsole implements "osdctl cluster console", which prints a
+// federated sign-in URL for the given cluster's cloud console so SREs can
+// jump straight from a support-role assumption into the browser.
+func newCmdConsole() *cobra.Command {
+	var destination string
+	var noCache bool
+	var sessionDuration int
+
+	consoleCmd := &cobra.Command{
+		Use:   "console <cluster-id>",
+		Short: "Generate a cloud console sign-in URL for a cluster",
+		Long:  "Assumes the cluster's support role and exchanges the resulting credentials for a federated sign-in URL into the cluster's cloud console.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clusterID := args[0]
+
+			signinURL, err := osdCloud.GetConsoleURLForClusterWithOptions(clusterID, destination, noCache, sessionDuration)
+			if err != nil {
+				return fmt.Errorf("failed to generate console sign-in URL for cluster %s: %w", clusterID, err)
+			}
+
+			fmt.Println(signinURL)
+			return nil
+		},
+	}
+
+	consoleCmd.Flags().StringVar(&destination, "destination", "", "Console page to land on after sign-in (defaults to the console home page)")
+	consoleCmd.Flags().BoolVar(&noCache, "no-cache", false, "Force a fresh backplane credential lookup instead of reusing a cached, not-yet-expired one")
+	consoleCmd.Flags().IntVar(&sessionDuration, "session-duration", osdCloud.DefaultSigninSessionDuration, "How long, in seconds, the generated console session stays valid for")
+
+	return consoleCmd
+}
@@ -1,16 +1,18 @@
 package osdCloud
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
-	awsSdk "github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/arn"
-	"github.com/aws/aws-sdk-go/service/sts"
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 
 	"github.com/openshift/osdctl/pkg/provider/aws"
 	"github.com/openshift/osdctl/pkg/utils"
@@ -20,34 +22,45 @@ const (
 	RhSreCcsAccessRolename        = "RH-SRE-CCS-Access"
 	RhTechnicalSupportAccess      = "RH-Technical-Support-Access"
 	OrganizationAccountAccessRole = "OrganizationAccountAccessRole"
+
+	// SourceArnEnvVar and SourceAccountEnvVar configure the confused-deputy
+	// protection sent as x-amz-source-arn/x-amz-source-account headers on
+	// every STS call in the assume-role chain. See SourceArnFromEnv.
+	SourceArnEnvVar     = "OSDCTL_STS_SOURCE_ARN"
+	SourceAccountEnvVar = "OSDCTL_STS_SOURCE_ACCOUNT"
 )
 
-// Creates a client for an assumed OrganizationAccountAccessRole
-func CreateOrganizationAccountAccessClient(client aws.Client, accountId, region, sessionName, partiton string) (aws.Client, error) {
+// SourceArnFromEnv and SourceAccountFromEnv read the confused-deputy
+// protection values operators can set via OSDCTL_STS_SOURCE_ARN and
+// OSDCTL_STS_SOURCE_ACCOUNT, per https://docs.aws.amazon.com/IAM/latest/UserGuide/confused-deputy.html.
+func SourceArnFromEnv() string {
+	return os.Getenv(SourceArnEnvVar)
+}
 
-	assumeRoleCredentials, err := GenerateOrganizationAccountAccessCredentials(client, accountId, sessionName, partiton)
-	if err != nil {
-		return nil, err
-	}
+func SourceAccountFromEnv() string {
+	return os.Getenv(SourceAccountEnvVar)
+}
 
-	organizationAccountAccessClient, err := aws.NewAwsClientWithInput(
-		&aws.AwsClientInput{
-			AccessKeyID:     *assumeRoleCredentials.AccessKeyId,
-			SecretAccessKey: *assumeRoleCredentials.SecretAccessKey,
-			SessionToken:    *assumeRoleCredentials.SessionToken,
-			Region:          *awsSdk.String(region),
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
+// Creates a client for an assumed OrganizationAccountAccessRole. The
+// assumed credentials are cached on disk (see NewCachedCredentialsProvider),
+// keyed by account and role, so repeated calls for the same account reuse
+// the cached session, until RefreshWindow before it expires, instead of
+// re-running GenerateOrganizationAccountAccessCredentials.
+func CreateOrganizationAccountAccessClient(ctx context.Context, client aws.Client, accountId, region, sessionName, partiton, sourceArn, sourceAccount string) (aws.Client, error) {
 
-	return organizationAccountAccessClient, nil
+	roleArn := aws.GenerateRoleARN(accountId, OrganizationAccountAccessRole)
+	cacheKey := CacheKey(accountId, roleArn, sessionName)
+
+	credsProvider := NewCachedCredentialsProvider(cacheKey, func() (*sts.Credentials, error) {
+		return GenerateOrganizationAccountAccessCredentials(ctx, client, accountId, sessionName, partiton, sourceArn, sourceAccount)
+	})
+
+	return aws.NewAwsClientWithCredentialsProvider(credsProvider, region, sourceArn, sourceAccount), nil
 }
 
 // Uses the provided IAM Client to try and assume OrganizationAccountAccessRole for the given AWS Account
 // This only works when the provided client is a user from the root account of an organization and the AWS account provided is a linked accounts within that organization
-func GenerateOrganizationAccountAccessCredentials(client aws.Client, accountId, sessionName, partition string) (*sts.Credentials, error) {
+func GenerateOrganizationAccountAccessCredentials(ctx context.Context, client aws.Client, accountId, sessionName, partition, sourceArn, sourceAccount string) (*sts.Credentials, error) {
 
 	roleArnString := aws.GenerateRoleARN(accountId, "OrganizationAccountAccessRole")
 
@@ -58,11 +71,12 @@ func GenerateOrganizationAccountAccessCredentials(client aws.Client, accountId,
 
 	targetRoleArn.Partition = partition
 
-	assumeRoleOutput, err := client.AssumeRole(
+	assumeRoleOutput, err := client.AssumeRole(ctx,
 		&sts.AssumeRoleInput{
 			RoleArn:         awsSdk.String(targetRoleArn.String()),
 			RoleSessionName: awsSdk.String(sessionName),
 		},
+		aws.WithSourceArn(sourceArn, sourceAccount),
 	)
 	if err != nil {
 		return nil, err
@@ -72,11 +86,31 @@ func GenerateOrganizationAccountAccessCredentials(client aws.Client, accountId,
 
 }
 
-// Uses the provided IAM Client to perform the Assume Role chain needed to get to a cluster's Support Role
-func GenerateSupportRoleCredentials(client aws.Client, awsAccountID, region, sessionName, targetRole string) (*sts.Credentials, error) {
+// Creates a client for an assumed cluster Support Role. Like
+// CreateOrganizationAccountAccessClient, the assumed credentials are cached
+// on disk, keyed by account and role, so repeated calls for the same cluster
+// reuse the cached session, until RefreshWindow before it expires, instead of
+// re-running the full jump->support chain in GenerateSupportRoleCredentials.
+func CreateSupportRoleClient(ctx context.Context, client aws.Client, awsAccountID, region, sessionName, targetRole, sourceArn, sourceAccount string) (aws.Client, error) {
+
+	cacheKey := CacheKey(awsAccountID, targetRole, sessionName)
+
+	credsProvider := NewCachedCredentialsProvider(cacheKey, func() (*sts.Credentials, error) {
+		return GenerateSupportRoleCredentials(ctx, client, awsAccountID, region, sessionName, targetRole, sourceArn, sourceAccount)
+	})
+
+	return aws.NewAwsClientWithCredentialsProvider(credsProvider, region, sourceArn, sourceAccount), nil
+}
+
+// Uses the provided IAM Client to perform the Assume Role chain needed to get to a cluster's Support Role.
+// The jump hop (see GenerateJumpRoleCredentials) and the final hop onto targetRole both go through
+// aws.NewAssumeRoleCredentials, which wraps stscreds.NewAssumeRoleProvider, so each hop's refresh-before-expiry
+// is handled by the SDK's credential cache instead of this function re-calling AssumeRole by hand. For a
+// disk-cached client built from this chain, see CreateSupportRoleClient.
+func GenerateSupportRoleCredentials(ctx context.Context, client aws.Client, awsAccountID, region, sessionName, targetRole, sourceArn, sourceAccount string) (*sts.Credentials, error) {
 
 	// Perform the Assume Role chain to get the jump
-	jumpRoleCreds, err := GenerateJumpRoleCredentials(client, awsAccountID, region, sessionName)
+	jumpRoleCreds, err := GenerateJumpRoleCredentials(ctx, client, awsAccountID, region, sessionName, sourceArn, sourceAccount)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +121,9 @@ func GenerateSupportRoleCredentials(client aws.Client, awsAccountID, region, ses
 			AccessKeyID:     *jumpRoleCreds.AccessKeyId,
 			SecretAccessKey: *jumpRoleCreds.SecretAccessKey,
 			SessionToken:    *jumpRoleCreds.SessionToken,
-			Region:          *awsSdk.String(region),
+			Region:          region,
+			SourceArn:       sourceArn,
+			SourceAccount:   sourceAccount,
 		},
 	)
 	if err != nil {
@@ -95,24 +131,24 @@ func GenerateSupportRoleCredentials(client aws.Client, awsAccountID, region, ses
 	}
 
 	// Assume target ManagedOpenShift-Support role in the cluster's AWS Account
-	targetAssumeRoleOutput, err := jumpRoleClient.AssumeRole(
-		&sts.AssumeRoleInput{
-			RoleArn:         awsSdk.String(targetRole),
-			RoleSessionName: awsSdk.String(sessionName),
-		},
-	)
+	targetCredsProvider := aws.NewAssumeRoleCredentials(jumpRoleClient, targetRole, sessionName, sourceArn, sourceAccount)
+
+	targetCreds, err := targetCredsProvider.Retrieve(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return targetAssumeRoleOutput.Credentials, nil
+	return toSTSCredentials(targetCreds), nil
 }
 
 // Preforms the Assume Role chain from IAM User to the Jump role
-// This sequence stays within the Red Hat account boundary, so a failure here indicates an internal misconfiguration
-func GenerateJumpRoleCredentials(client aws.Client, awsAccountID, region, sessionName string) (*sts.Credentials, error) {
+// This sequence stays within the Red Hat account boundary, so a failure here indicates an internal misconfiguration.
+// Both hops' credentials come from aws.NewAssumeRoleCredentials (stscreds.NewAssumeRoleProvider under an
+// aws.CredentialsCache) rather than open-coded AssumeRole calls, so refresh-before-expiry is handled by the
+// SDK instead of by this function.
+func GenerateJumpRoleCredentials(ctx context.Context, client aws.Client, awsAccountID, region, sessionName, sourceArn, sourceAccount string) (*sts.Credentials, error) {
 
-	callerIdentityOutput, err := client.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	callerIdentityOutput, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
 		return nil, err
 	}
@@ -124,56 +160,53 @@ func GenerateJumpRoleCredentials(client aws.Client, awsAccountID, region, sessio
 
 	// Assume RH-SRE-CCS-Access role
 	sreCcsAccessRoleArn := aws.GenerateRoleARN(sreUserArn.AccountID, RhSreCcsAccessRolename)
-	sreCcsAccessAssumeRoleOutput, err := client.AssumeRole(
-		&sts.AssumeRoleInput{
-			RoleArn:         awsSdk.String(sreCcsAccessRoleArn),
-			RoleSessionName: awsSdk.String(sessionName),
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
+	sreCcsCredsProvider := aws.NewAssumeRoleCredentials(client, sreCcsAccessRoleArn, sessionName, sourceArn, sourceAccount)
 
-	// Build client for RH-SRE-CCS-Access role
-	sreCcsAccessRoleClient, err := aws.NewAwsClientWithInput(
-		&aws.AwsClientInput{
-			AccessKeyID:     *sreCcsAccessAssumeRoleOutput.Credentials.AccessKeyId,
-			SecretAccessKey: *sreCcsAccessAssumeRoleOutput.Credentials.SecretAccessKey,
-			SessionToken:    *sreCcsAccessAssumeRoleOutput.Credentials.SessionToken,
-			Region:          *awsSdk.String(region),
-		},
-	)
-	if err != nil {
-		return nil, err
-	}
+	// Build client for RH-SRE-CCS-Access role directly off the cached provider, so it
+	// re-assumes the role itself once the credentials it handed out expire.
+	sreCcsAccessRoleClient := aws.NewAwsClientWithCredentialsProvider(sreCcsCredsProvider, region, sourceArn, sourceAccount)
 
 	// Assume jump role
-	// This will be different between stage and prod. There's probably a better way to do this that isn't hardcoding
-	jumproleAccountID := os.Getenv("JUMPROLE_ACCOUNT_ID")
+	// This will be different between stage and prod, which is why it's resolved through
+	// a SecretSource rather than hardcoded - teams can rotate the jump-role account ID
+	// (e.g. by updating Vault) without shipping a new osdctl build.
+	jumproleAccountID, err := DefaultSecretSource.Get("jumprole_account_id")
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve jumprole_account_id: %s", err)
+	}
 	jumpRoleArn := aws.GenerateRoleARN(jumproleAccountID, RhTechnicalSupportAccess)
-	jumpAssumeRoleOutput, err := sreCcsAccessRoleClient.AssumeRole(
-		&sts.AssumeRoleInput{
-			RoleArn:         awsSdk.String(jumpRoleArn),
-			RoleSessionName: awsSdk.String(sessionName),
-		},
-	)
+	jumpCredsProvider := aws.NewAssumeRoleCredentials(sreCcsAccessRoleClient, jumpRoleArn, sessionName, sourceArn, sourceAccount)
+
+	jumpCreds, err := jumpCredsProvider.Retrieve(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return jumpAssumeRoleOutput.Credentials, nil
+	return toSTSCredentials(jumpCreds), nil
+}
 
+// toSTSCredentials adapts an aws.Credentials (the shape stscreds/CredentialsCache
+// deal in) back to the *sts.Credentials shape the rest of this package's
+// functions return, so callers don't need to know a given hop was resolved
+// through a cached AssumeRoleProvider rather than a raw AssumeRole call.
+func toSTSCredentials(creds awsSdk.Credentials) *sts.Credentials {
+	return &sts.Credentials{
+		AccessKeyId:     awsSdk.String(creds.AccessKeyID),
+		SecretAccessKey: awsSdk.String(creds.SecretAccessKey),
+		SessionToken:    awsSdk.String(creds.SessionToken),
+		Expiration:      awsSdk.Time(creds.Expires),
+	}
 }
 
 // Uses the current IAM ARN to generate a role name. This should end up being RH-SRE-$kerberosID
-func GenerateRoleSessionName(client aws.Client) (string, error) {
+func GenerateRoleSessionName(ctx context.Context, client aws.Client) (string, error) {
 
-	callerIdentityOutput, err := client.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	callerIdentityOutput, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
 		return "", err
 	}
 
-	roleArn, err := arn.Parse(awsSdk.StringValue(callerIdentityOutput.Arn))
+	roleArn, err := arn.Parse(awsSdk.ToString(callerIdentityOutput.Arn))
 	if err != nil {
 		return "", err
 	}
@@ -206,22 +239,90 @@ type awsCredentialsResponse struct {
 	Expiration      string `json:"Expiration" yaml:"Expiration"`
 }
 
-// Creates an AWS client based on a clusterid
-// Requires previous log on to the correct api server via ocm login
-// and tunneling to the backplane
-func CreateAWSClient(clusterID string) (aws.Client, error) {
+// awsCredentialProvider implements CloudCredentialProvider for AWS-backed
+// OSD/ROSA clusters using the existing jump-role -> support-role chain.
+type awsCredentialProvider struct{}
+
+func newAWSCredentialProvider() CloudCredentialProvider {
+	return &awsCredentialProvider{}
+}
+
+func (p *awsCredentialProvider) AssumeSupportRole(clusterID, sessionName string) (*CloudClient, error) {
+	client, err := CreateAWSClient(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudClient{Provider: CloudProviderAWS, AWS: client}, nil
+}
+
+func (p *awsCredentialProvider) GetConsoleURL(clusterID string) (string, error) {
+	return GetConsoleURLForCluster(clusterID, "")
+}
+
+func (p *awsCredentialProvider) GetCredentials(clusterID string) (*CloudCredentials, error) {
+	_, awsCredentials, err := fetchBackplaneAWSCredentials(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudCredentials{
+		Provider: CloudProviderAWS,
+		AWS: &sts.Credentials{
+			AccessKeyId:     awsSdk.String(awsCredentials.AccessKeyId),
+			SecretAccessKey: awsSdk.String(awsCredentials.SecretAccessKey),
+			SessionToken:    awsSdk.String(awsCredentials.SessionToken),
+		},
+	}, nil
+}
+
+// GetConsoleURLForCluster fetches a cluster's backplane-issued AWS
+// credentials and exchanges them for a federated AWS Web Console sign-in
+// URL, so SREs can jump straight from a support-role assumption into the
+// console without juggling env vars.
+func GetConsoleURLForCluster(clusterID, destination string) (string, error) {
+	return GetConsoleURLForClusterWithOptions(clusterID, destination, false, 0)
+}
+
+// GetConsoleURLForClusterWithOptions is GetConsoleURLForCluster with a
+// noCache escape hatch, for callers (e.g. `--no-cache`) that want to force a
+// fresh backplane lookup rather than reusing a cached, not-yet-expired
+// credential, and a sessionDuration (in seconds) for how long the resulting
+// console session stays valid; zero defaults to DefaultSigninSessionDuration.
+func GetConsoleURLForClusterWithOptions(clusterID, destination string, noCache bool, sessionDuration int) (string, error) {
+	awsCredentials, _, err := fetchCachedBackplaneCredentials(clusterID, noCache)
+	if err != nil {
+		return "", err
+	}
+
+	creds := &sts.Credentials{
+		AccessKeyId:     awsSdk.String(awsCredentials.AccessKeyId),
+		SecretAccessKey: awsSdk.String(awsCredentials.SecretAccessKey),
+		SessionToken:    awsSdk.String(awsCredentials.SessionToken),
+	}
+
+	return GenerateConsoleSigninURL(creds, destination, sessionDuration)
+}
+
+// fetchBackplaneAWSCredentials retrieves the backplane-issued cloud and AWS
+// credential responses for a cluster. Requires previous log on to the
+// correct api server via ocm login and tunneling to the backplane.
+func fetchBackplaneAWSCredentials(clusterID string) (*cloudCredentialsResponse, *awsCredentialsResponse, error) {
 	token, err := utils.GetOCMApiServerToken()
+	if err != nil {
+		return nil, nil, err
+	}
 
 	getUrl, err := utils.GetBackplaneURL(clusterID)
 	if err != nil {
-		return nil, fmt.Errorf("Unable to retrieve backplane URL for cluster %s: %s", clusterID, err)
+		return nil, nil, fmt.Errorf("Unable to retrieve backplane URL for cluster %s: %s", clusterID, err)
 	}
 
 	client := http.Client{}
 
-	request, _ := http.NewRequest("GET", getUrl, nil)
+	request, err := http.NewRequest("GET", getUrl, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	request.Header.Set("Authorization", "Bearer "+*token)
@@ -229,7 +330,7 @@ func CreateAWSClient(clusterID string) (aws.Client, error) {
 
 	resp, err := client.Do(request)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var cloudCredentials cloudCredentialsResponse
@@ -238,23 +339,95 @@ func CreateAWSClient(clusterID string) (aws.Client, error) {
 		defer resp.Body.Close()
 		bodyBytes, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		cloudCredentials = cloudCredentialsResponse{}
 
 		err = json.Unmarshal(bodyBytes, &cloudCredentials)
 		if err != nil {
-			return nil, fmt.Errorf("Unable to unmarshal cloud credentials: %s", err)
+			return nil, nil, fmt.Errorf("Unable to unmarshal cloud credentials: %s", err)
 		}
 
 		err = json.Unmarshal([]byte(*cloudCredentials.Credentials), &awsCredentials)
 		if err != nil {
-			return nil, fmt.Errorf("Unable to unmarshal aws credentials: %s", err)
+			return nil, nil, fmt.Errorf("Unable to unmarshal aws credentials: %s", err)
 		}
 	}
 
-	input := aws.AwsClientInput{AccessKeyID: awsCredentials.AccessKeyId, SecretAccessKey: awsCredentials.SecretAccessKey, SessionToken: awsCredentials.SessionToken, Region: *cloudCredentials.Region}
+	return &cloudCredentials, &awsCredentials, nil
+}
+
+// Creates an AWS client based on a clusterid
+// Requires previous log on to the correct api server via ocm login
+// and tunneling to the backplane
+func CreateAWSClient(clusterID string) (aws.Client, error) {
+	return CreateAWSClientWithOptions(clusterID, false)
+}
+
+// CreateAWSClientWithOptions is CreateAWSClient with a noCache escape hatch
+// for when a caller (e.g. `--no-cache`) wants to force a fresh backplane
+// lookup rather than reusing a cached, not-yet-expired credential.
+func CreateAWSClientWithOptions(clusterID string, noCache bool) (aws.Client, error) {
+	awsCredentials, region, err := fetchCachedBackplaneCredentials(clusterID, noCache)
+	if err != nil {
+		return nil, err
+	}
+
+	input := aws.AwsClientInput{
+		AccessKeyID:     awsCredentials.AccessKeyId,
+		SecretAccessKey: awsCredentials.SecretAccessKey,
+		SessionToken:    awsCredentials.SessionToken,
+		Region:          region,
+		SourceArn:       SourceArnFromEnv(),
+		SourceAccount:   SourceAccountFromEnv(),
+	}
 
 	return aws.NewAwsClientWithInput(&input)
-}
\ No newline at end of file
+}
+
+// fetchCachedBackplaneCredentials is the shared noCache-aware lookup behind
+// CreateAWSClientWithOptions and GetConsoleURLForClusterWithOptions: it
+// serves a cached, not-yet-expired backplane credential when one exists,
+// and otherwise fetches (and, unless noCache is set, caches) a fresh one.
+func fetchCachedBackplaneCredentials(clusterID string, noCache bool) (*awsCredentialsResponse, string, error) {
+	cacheKey := CacheKey(clusterID, "backplane", "")
+
+	var entry backplaneCredentialCacheEntry
+	if noCache || readCache(cacheKey, &entry) != nil || entry.isExpired() {
+		cloudCredentials, awsCredentials, err := fetchBackplaneAWSCredentials(clusterID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		entry = backplaneCredentialCacheEntry{Credentials: *awsCredentials, Region: *cloudCredentials.Region}
+
+		if !noCache {
+			if err := writeCache(cacheKey, &entry); err != nil {
+				return nil, "", fmt.Errorf("unable to cache backplane credentials: %s", err)
+			}
+		}
+	}
+
+	return &entry.Credentials, entry.Region, nil
+}
+
+// backplaneCredentialCacheEntry is the on-disk shape cached by
+// CreateAWSClientWithOptions, keyed by cluster ID.
+type backplaneCredentialCacheEntry struct {
+	Credentials awsCredentialsResponse `json:"credentials"`
+	Region      string                 `json:"region"`
+}
+
+func (e *backplaneCredentialCacheEntry) isExpired() bool {
+	if e.Credentials.Expiration == "" {
+		return true
+	}
+
+	expiration, err := time.Parse(time.RFC3339, e.Credentials.Expiration)
+	if err != nil {
+		return true
+	}
+
+	return time.Now().Add(RefreshWindow()).After(expiration)
+}
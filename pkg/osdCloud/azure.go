@@ -0,0 +1,48 @@
+package osdCloud
+
+import (
+	"fmt"
+
+	"github.com/openshift/osdctl/pkg/provider/azure"
+)
+
+// azureCredentialProvider implements CloudCredentialProvider for ARO
+// clusters by obtaining a federated/managed identity credential via the az
+// CLI rather than an AWS-style assume-role chain.
+type azureCredentialProvider struct{}
+
+func newAzureCredentialProvider() CloudCredentialProvider {
+	return &azureCredentialProvider{}
+}
+
+func (p *azureCredentialProvider) AssumeSupportRole(clusterID, sessionName string) (*CloudClient, error) {
+	resourceGroup, err := azure.GenerateSupportResourceGroup(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := azure.NewFederatedClient(resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudClient{Provider: CloudProviderAzure, Azure: client}, nil
+}
+
+func (p *azureCredentialProvider) GetConsoleURL(clusterID string) (string, error) {
+	return "", fmt.Errorf("GetConsoleURL is not yet implemented for Azure")
+}
+
+func (p *azureCredentialProvider) GetCredentials(clusterID string) (*CloudCredentials, error) {
+	resourceGroup, err := azure.GenerateSupportResourceGroup(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := azure.GenerateFederatedCredentials(resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudCredentials{Provider: CloudProviderAzure, Azure: token}, nil
+}
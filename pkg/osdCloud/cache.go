@@ -0,0 +1,189 @@
+package osdCloud
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// DefaultRefreshWindow is how far ahead of a cached credential's expiration
+// osdctl proactively re-runs the assume-role chain, rather than waiting for
+// it to expire mid-command, when RefreshWindowEnvVar isn't set.
+const DefaultRefreshWindow = 5 * time.Minute
+
+// RefreshWindowEnvVar overrides DefaultRefreshWindow with a duration string
+// (e.g. "10m", "90s") parsed by time.ParseDuration. See RefreshWindow.
+const RefreshWindowEnvVar = "OSDCTL_CREDENTIAL_REFRESH_WINDOW"
+
+// RefreshWindow returns the configured refresh window: the value of
+// RefreshWindowEnvVar if set and parseable as a time.Duration, otherwise
+// DefaultRefreshWindow.
+func RefreshWindow() time.Duration {
+	if value := os.Getenv(RefreshWindowEnvVar); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+
+	return DefaultRefreshWindow
+}
+
+// cachedCredentialsProvider implements aws.CredentialsProvider, transparently
+// caching the assume-role chain's output on disk (keyed by cluster ID, role
+// ARN, and session name). Wrapping it in aws.NewCredentialsCache gives the
+// in-process refresh-before-expiry behavior; the disk cache on top of that
+// avoids re-running the full jump->support chain, and minting a fresh token,
+// on every separate CreateSupportRoleClient or
+// CreateOrganizationAccountAccessClient invocation.
+type cachedCredentialsProvider struct {
+	cacheKey string
+	generate func() (*sts.Credentials, error)
+}
+
+// NewCachedCredentialsProvider wraps generate (e.g. a closure around
+// GenerateOrganizationAccountAccessCredentials or GenerateSupportRoleCredentials,
+// as CreateOrganizationAccountAccessClient does) in an aws.CredentialsProvider
+// that caches its result on disk under cacheKey.
+func NewCachedCredentialsProvider(cacheKey string, generate func() (*sts.Credentials, error)) awsSdk.CredentialsProvider {
+	return awsSdk.NewCredentialsCache(&cachedCredentialsProvider{cacheKey: cacheKey, generate: generate})
+}
+
+func (p *cachedCredentialsProvider) Retrieve(ctx context.Context) (awsSdk.Credentials, error) {
+	if cached, ok := readCachedCredentials(p.cacheKey); ok {
+		return toCredentials(cached), nil
+	}
+
+	creds, err := p.generate()
+	if err != nil {
+		return awsSdk.Credentials{}, err
+	}
+
+	if err := writeCachedCredentials(p.cacheKey, creds); err != nil {
+		return awsSdk.Credentials{}, fmt.Errorf("unable to cache credentials: %s", err)
+	}
+
+	return toCredentials(creds), nil
+}
+
+func toCredentials(creds *sts.Credentials) awsSdk.Credentials {
+	return awsSdk.Credentials{
+		AccessKeyID:     *creds.AccessKeyId,
+		SecretAccessKey: *creds.SecretAccessKey,
+		SessionToken:    *creds.SessionToken,
+		CanExpire:       true,
+		Expires:         *creds.Expiration,
+		Source:          "osdCloudCachedCredentialsProvider",
+	}
+}
+
+// CacheKey derives the on-disk cache key for an assume-role chain's output,
+// scoped to the cluster, the role being assumed, and the session name so
+// that concurrent sessions for different roles don't collide.
+func CacheKey(clusterID, roleArn, sessionName string) string {
+	sum := sha256.Sum256([]byte(clusterID + "|" + roleArn + "|" + sessionName))
+	return fmt.Sprintf("%x", sum)
+}
+
+func cacheDir() (string, error) {
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(userCacheDir, "osdctl", "credentials")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func cacheFilePath(cacheKey string) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, cacheKey+".json"), nil
+}
+
+func readCachedCredentials(cacheKey string) (*sts.Credentials, bool) {
+	var creds sts.Credentials
+	if err := readCache(cacheKey, &creds); err != nil {
+		return nil, false
+	}
+
+	if creds.Expiration == nil || time.Now().Add(RefreshWindow()).After(*creds.Expiration) {
+		return nil, false
+	}
+
+	return &creds, true
+}
+
+func writeCachedCredentials(cacheKey string, creds *sts.Credentials) error {
+	return writeCache(cacheKey, creds)
+}
+
+// readCache and writeCache are the generic disk-cache primitives that back
+// readCachedCredentials/writeCachedCredentials, also reused for caching the
+// raw backplane credential response in CreateAWSClient.
+func readCache(cacheKey string, v interface{}) error {
+	path, err := cacheFilePath(cacheKey)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func writeCache(cacheKey string, v interface{}) error {
+	path, err := cacheFilePath(cacheKey)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// PurgeCredentialCache removes every cached credential osdctl has written to
+// disk. Used by `osdctl cloud credentials purge` and the --no-cache flag's
+// counterpart for invalidating stale entries.
+func PurgeCredentialCache() error {
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return fmt.Errorf("unable to remove cached credential %s: %s", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package osdCloud
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackplaneCredentialCacheEntryIsExpired(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiration string
+		want       bool
+	}{
+		{"no expiration set", "", true},
+		{"malformed expiration", "not-a-timestamp", true},
+		{"already expired", time.Now().Add(-time.Minute).Format(time.RFC3339), true},
+		{"expires inside RefreshWindow", time.Now().Add(RefreshWindow() / 2).Format(time.RFC3339), true},
+		{"expires well after RefreshWindow", time.Now().Add(RefreshWindow() * 2).Format(time.RFC3339), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := backplaneCredentialCacheEntry{Credentials: awsCredentialsResponse{Expiration: tt.expiration}}
+			if got := entry.isExpired(); got != tt.want {
+				t.Errorf("isExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCacheKeyIsDeterministicAndScoped(t *testing.T) {
+	a := CacheKey("cluster-a", "arn:aws:iam::111111111111:role/Foo", "session")
+	b := CacheKey("cluster-a", "arn:aws:iam::111111111111:role/Foo", "session")
+	if a != b {
+		t.Fatalf("CacheKey is not deterministic: %s != %s", a, b)
+	}
+
+	if c := CacheKey("cluster-b", "arn:aws:iam::111111111111:role/Foo", "session"); a == c {
+		t.Fatalf("CacheKey collided across different cluster IDs")
+	}
+
+	if c := CacheKey("cluster-a", "arn:aws:iam::222222222222:role/Foo", "session"); a == c {
+		t.Fatalf("CacheKey collided across different role ARNs")
+	}
+}
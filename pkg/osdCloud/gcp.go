@@ -0,0 +1,48 @@
+package osdCloud
+
+import (
+	"fmt"
+
+	"github.com/openshift/osdctl/pkg/provider/gcp"
+)
+
+// gcpCredentialProvider implements CloudCredentialProvider for GCP-backed
+// OSD clusters by impersonating the cluster's support service account via
+// the IAM Credentials API.
+type gcpCredentialProvider struct{}
+
+func newGCPCredentialProvider() CloudCredentialProvider {
+	return &gcpCredentialProvider{}
+}
+
+func (p *gcpCredentialProvider) AssumeSupportRole(clusterID, sessionName string) (*CloudClient, error) {
+	serviceAccount, err := gcp.GenerateSupportServiceAccountEmail(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := gcp.NewImpersonatedClient(serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudClient{Provider: CloudProviderGCP, GCP: client}, nil
+}
+
+func (p *gcpCredentialProvider) GetConsoleURL(clusterID string) (string, error) {
+	return "", fmt.Errorf("GetConsoleURL is not yet implemented for GCP")
+}
+
+func (p *gcpCredentialProvider) GetCredentials(clusterID string) (*CloudCredentials, error) {
+	serviceAccount, err := gcp.GenerateSupportServiceAccountEmail(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := gcp.GenerateImpersonatedCredentials(serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudCredentials{Provider: CloudProviderGCP, GCP: token}, nil
+}
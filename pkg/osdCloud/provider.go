@@ -0,0 +1,93 @@
+package osdCloud
+
+import (
+	"fmt"
+
+	"cloud.google.com/go/iam/credentials/apiv1/credentialspb"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/openshift/osdctl/pkg/provider/aws"
+	"github.com/openshift/osdctl/pkg/provider/azure"
+	"github.com/openshift/osdctl/pkg/provider/gcp"
+	"github.com/openshift/osdctl/pkg/utils"
+)
+
+// CloudProvider identifies which hyperscaler a cluster was provisioned on.
+type CloudProvider string
+
+const (
+	CloudProviderAWS   CloudProvider = "aws"
+	CloudProviderGCP   CloudProvider = "gcp"
+	CloudProviderAzure CloudProvider = "azure"
+)
+
+// CloudClient is the typed client AssumeSupportRole/CreateCloudClient hand
+// back once a cluster's cloud provider is known. Exactly one of AWS/GCP/Azure
+// is set, matching Provider, so callers can switch on Provider (or just read
+// the field they expect for a known cluster type) instead of type-asserting
+// a bare interface{}.
+type CloudClient struct {
+	Provider CloudProvider
+	AWS      aws.Client
+	GCP      *gcp.Client
+	Azure    *azure.Client
+}
+
+// CloudCredentials is the typed counterpart to CloudClient for GetCredentials:
+// the raw credentials backing a CloudClient, in whatever form is native to
+// the cloud. Exactly one of AWS/GCP/Azure is set, matching Provider.
+type CloudCredentials struct {
+	Provider CloudProvider
+	AWS      *sts.Credentials
+	GCP      *credentialspb.GenerateAccessTokenResponse
+	Azure    *azure.FederatedToken
+}
+
+// CloudCredentialProvider is implemented once per hyperscaler so that the rest of
+// osdctl can assume a cluster's support role and fetch a console URL without
+// caring whether the cluster lives on AWS, GCP, or Azure.
+type CloudCredentialProvider interface {
+	// AssumeSupportRole performs whatever credential chain is needed to reach
+	// a cluster's support role/identity and returns a client for that cloud.
+	AssumeSupportRole(clusterID, sessionName string) (*CloudClient, error)
+
+	// GetConsoleURL returns a sign-in URL for the cluster's cloud console.
+	GetConsoleURL(clusterID string) (string, error)
+
+	// GetCredentials returns the raw credentials backing AssumeSupportRole,
+	// in whatever form is native to the cloud (e.g. *sts.Credentials for AWS).
+	GetCredentials(clusterID string) (*CloudCredentials, error)
+}
+
+// NewCloudCredentialProvider looks up a cluster's cloud provider via OCM and
+// returns the CloudCredentialProvider implementation for it.
+func NewCloudCredentialProvider(clusterID string) (CloudCredentialProvider, error) {
+	provider, err := utils.GetClusterCloudProvider(clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine cloud provider for cluster %s: %s", clusterID, err)
+	}
+
+	switch CloudProvider(provider) {
+	case CloudProviderAWS:
+		return newAWSCredentialProvider(), nil
+	case CloudProviderGCP:
+		return newGCPCredentialProvider(), nil
+	case CloudProviderAzure:
+		return newAzureCredentialProvider(), nil
+	default:
+		return nil, fmt.Errorf("unsupported cloud provider %q for cluster %s", provider, clusterID)
+	}
+}
+
+// CreateCloudClient inspects the given cluster's cloud provider via OCM and
+// dispatches to the right backend, returning a typed CloudClient for that
+// cloud. This replaces the AWS-only CreateAWSClient for callers that need to
+// work transparently across AWS, GCP, and Azure OSD/ROSA/ARO clusters.
+func CreateCloudClient(clusterID string) (*CloudClient, error) {
+	provider, err := NewCloudCredentialProvider(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.AssumeSupportRole(clusterID, "")
+}
@@ -0,0 +1,177 @@
+package osdCloud
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SecretSource resolves jump/support role bootstrap material - currently
+// just the jump-role account ID, but the same path a given backend takes to
+// fetch one is what future bootstrap secrets (e.g. a support_role_template)
+// would reuse - without GenerateJumpRoleCredentials needing to know whether
+// it came from an env var, Vault, or a local password manager.
+type SecretSource interface {
+	// Get resolves a named secret (e.g. "jumprole_account_id").
+	Get(key string) (string, error)
+}
+
+// DefaultSecretSource is the SecretSource used by GenerateJumpRoleCredentials
+// when callers don't configure one explicitly. It defaults to env vars to
+// match historical behavior.
+var DefaultSecretSource SecretSource = NewEnvSecretSource("")
+
+// envSecretSource resolves secrets from environment variables named by the
+// upper-cased key, optionally prefixed (e.g. key "jumprole_account_id" with prefix
+// "OSDCTL_" reads OSDCTL_JUMPROLE_ACCOUNT_ID).
+type envSecretSource struct {
+	prefix string
+}
+
+// NewEnvSecretSource returns a SecretSource backed by environment variables.
+func NewEnvSecretSource(prefix string) SecretSource {
+	return &envSecretSource{prefix: prefix}
+}
+
+func (s *envSecretSource) Get(key string) (string, error) {
+	envVar := s.prefix + strings.ToUpper(key)
+
+	value, ok := os.LookupEnv(envVar)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", envVar)
+	}
+
+	return value, nil
+}
+
+// vaultSecretSource resolves secrets from a HashiCorp Vault KV v2 mount,
+// authenticating via approle or an existing token and caching the lease for
+// its reported TTL so repeated lookups don't re-authenticate every time.
+type vaultSecretSource struct {
+	addr     string
+	path     string
+	roleID   string
+	secretID string
+	token    string
+
+	mu       sync.Mutex
+	leaseTTL time.Duration
+	cachedAt time.Time
+	cachedKV map[string]string
+}
+
+// VaultConfig configures a Vault-backed SecretSource.
+type VaultConfig struct {
+	// Addr is the Vault server address, e.g. https://vault.example.com.
+	Addr string
+	// Path is the KV v2 secret path holding jumprole_account_id,
+	// support_role_template, etc.
+	Path string
+	// Token authenticates directly, when set. Otherwise RoleID/SecretID are
+	// used to log in via the approle auth method.
+	Token    string
+	RoleID   string
+	SecretID string
+}
+
+// NewVaultSecretSource returns a SecretSource backed by a Vault KV v2 path.
+func NewVaultSecretSource(cfg VaultConfig) SecretSource {
+	return &vaultSecretSource{
+		addr:     cfg.Addr,
+		path:     cfg.Path,
+		roleID:   cfg.RoleID,
+		secretID: cfg.SecretID,
+		token:    cfg.Token,
+	}
+}
+
+func (s *vaultSecretSource) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cachedKV == nil || time.Since(s.cachedAt) > s.leaseTTL {
+		kv, ttl, err := s.fetch()
+		if err != nil {
+			return "", err
+		}
+
+		s.cachedKV = kv
+		s.leaseTTL = ttl
+		s.cachedAt = time.Now()
+	}
+
+	value, ok := s.cachedKV[key]
+	if !ok {
+		return "", fmt.Errorf("secret %q not found at Vault path %s", key, s.path)
+	}
+
+	return value, nil
+}
+
+// fetch authenticates against Vault (approle if no static token was given)
+// and reads the configured KV v2 path, returning its data and lease TTL.
+func (s *vaultSecretSource) fetch() (map[string]string, time.Duration, error) {
+	token := s.token
+
+	if token == "" {
+		loginToken, err := s.approleLogin()
+		if err != nil {
+			return nil, 0, fmt.Errorf("unable to authenticate to Vault via approle: %s", err)
+		}
+		token = loginToken
+	}
+
+	return vaultReadKV(s.addr, s.path, token)
+}
+
+// approleLogin exchanges a RoleID/SecretID pair for a Vault token via the
+// approle auth method.
+func (s *vaultSecretSource) approleLogin() (string, error) {
+	return vaultApproleLogin(s.addr, s.roleID, s.secretID)
+}
+
+// passSecretSource resolves secrets from a local `pass`/1Password CLI entry,
+// letting operators keep jump-role bootstrap material in whichever password
+// manager their team already uses instead of shipping it in osdctl.
+type passSecretSource struct {
+	// entryPrefix is prepended to the key to build the pass/op entry name,
+	// e.g. "osdctl/" + "jumprole_account_id".
+	entryPrefix string
+	// command is "pass" or "op", selecting which CLI to shell out to.
+	command string
+}
+
+// NewPassSecretSource returns a SecretSource backed by the `pass` CLI.
+func NewPassSecretSource(entryPrefix string) SecretSource {
+	return &passSecretSource{entryPrefix: entryPrefix, command: "pass"}
+}
+
+// NewOnePasswordSecretSource returns a SecretSource backed by the 1Password
+// `op` CLI, reading the given vault item's field named by key.
+func NewOnePasswordSecretSource(entryPrefix string) SecretSource {
+	return &passSecretSource{entryPrefix: entryPrefix, command: "op"}
+}
+
+func (s *passSecretSource) Get(key string) (string, error) {
+	entry := s.entryPrefix + key
+
+	var cmd *exec.Cmd
+	switch s.command {
+	case "pass":
+		cmd = exec.Command("pass", "show", entry)
+	case "op":
+		cmd = exec.Command("op", "read", entry)
+	default:
+		return "", fmt.Errorf("unsupported secret command %q", s.command)
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("unable to read secret %q via %s: %s", entry, s.command, err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,94 @@
+package osdCloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// vaultApproleLogin exchanges a RoleID/SecretID pair for a Vault token via
+// the approle auth method (POST /v1/auth/approle/login).
+func vaultApproleLogin(addr, roleID, secretID string) (string, error) {
+	body, err := json.Marshal(map[string]string{"role_id": roleID, "secret_id": secretID})
+	if err != nil {
+		return "", err
+	}
+
+	request, err := http.NewRequest("POST", addr+"/v1/auth/approle/login", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Vault approle login returned %s: %s", resp.Status, string(respBody))
+	}
+
+	var loginResponse struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := json.Unmarshal(respBody, &loginResponse); err != nil {
+		return "", fmt.Errorf("unable to unmarshal Vault approle login response: %s", err)
+	}
+
+	return loginResponse.Auth.ClientToken, nil
+}
+
+// vaultReadKV reads a KV v2 secret path (GET /v1/secret/data/<path>) and
+// returns its string-valued data along with the lease's reported TTL, or
+// five minutes when Vault reports a TTL of zero.
+func vaultReadKV(addr, path, token string) (map[string]string, time.Duration, error) {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/v1/secret/data/%s", addr, path), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	request.Header.Set("X-Vault-Token", token)
+
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("Vault read of %s returned %s: %s", path, resp.Status, string(respBody))
+	}
+
+	var readResponse struct {
+		LeaseDuration int `json:"lease_duration"`
+		Data          struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &readResponse); err != nil {
+		return nil, 0, fmt.Errorf("unable to unmarshal Vault read response: %s", err)
+	}
+
+	ttl := time.Duration(readResponse.LeaseDuration) * time.Second
+	if ttl == 0 {
+		ttl = 5 * time.Minute
+	}
+
+	return readResponse.Data.Data, ttl, nil
+}
@@ -0,0 +1,58 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// terminalBuildHandler returns a Build handler that does nothing, for
+// exercising a Stack's Build step in isolation.
+func terminalBuildHandler() middleware.BuildHandlerFunc {
+	return func(ctx context.Context, in middleware.BuildInput) (middleware.BuildOutput, middleware.Metadata, error) {
+		return middleware.BuildOutput{}, middleware.Metadata{}, nil
+	}
+}
+
+func TestSourceArnHeaderMiddlewareSetsHeaders(t *testing.T) {
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	if err := sourceArnHeaderMiddleware("arn:aws:iam::123456789012:role/Foo", "123456789012")(stack); err != nil {
+		t.Fatalf("unable to register middleware: %s", err)
+	}
+
+	req := &smithyhttp.Request{Request: &http.Request{Header: http.Header{}}}
+
+	if _, _, err := stack.Build.HandleMiddleware(context.Background(), middleware.BuildInput{Request: req}, terminalBuildHandler()); err != nil {
+		t.Fatalf("unexpected error invoking Build step: %s", err)
+	}
+
+	if got := req.Header.Get("x-amz-source-arn"); got != "arn:aws:iam::123456789012:role/Foo" {
+		t.Errorf("x-amz-source-arn = %q, want %q", got, "arn:aws:iam::123456789012:role/Foo")
+	}
+	if got := req.Header.Get("x-amz-source-account"); got != "123456789012" {
+		t.Errorf("x-amz-source-account = %q, want %q", got, "123456789012")
+	}
+}
+
+func TestSourceArnHeaderMiddlewareLeavesHeadersUnsetWhenEmpty(t *testing.T) {
+	stack := middleware.NewStack("test", smithyhttp.NewStackRequest)
+	if err := sourceArnHeaderMiddleware("", "")(stack); err != nil {
+		t.Fatalf("unable to register middleware: %s", err)
+	}
+
+	req := &smithyhttp.Request{Request: &http.Request{Header: http.Header{}}}
+
+	if _, _, err := stack.Build.HandleMiddleware(context.Background(), middleware.BuildInput{Request: req}, terminalBuildHandler()); err != nil {
+		t.Fatalf("unexpected error invoking Build step: %s", err)
+	}
+
+	if got := req.Header.Get("x-amz-source-arn"); got != "" {
+		t.Errorf("x-amz-source-arn = %q, want unset", got)
+	}
+	if got := req.Header.Get("x-amz-source-account"); got != "" {
+		t.Errorf("x-amz-source-account = %q, want unset", got)
+	}
+}
@@ -0,0 +1,73 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// Client wraps an Azure federated/managed identity credential for a
+// cluster's support resource group, analogous to aws.Client for AWS.
+type Client struct {
+	ResourceGroup string
+	AccessToken   string
+}
+
+type FederatedToken struct {
+	AccessToken string `json:"accessToken"`
+	Expiresin   int    `json:"expiresIn"`
+}
+
+// GenerateSupportResourceGroup derives the managed resource group osdctl
+// should authenticate against for the given ARO cluster.
+func GenerateSupportResourceGroup(clusterID string) (string, error) {
+	if clusterID == "" {
+		return "", fmt.Errorf("clusterID is required")
+	}
+
+	return fmt.Sprintf("aro-%s-support", clusterID), nil
+}
+
+// azureResourceManagerResource is the audience `az account get-access-token`
+// mints a token for by default; it's passed explicitly via --resource since
+// `az` has no notion of scoping a token request to a single resource group -
+// that scoping happens later, on the ARM calls this token authenticates.
+const azureResourceManagerResource = "https://management.azure.com/"
+
+// GenerateFederatedCredentials shells out to the az CLI to mint a federated
+// credential/managed identity token for the Azure Resource Manager audience,
+// for callers that will use it to act against resourceGroup. This mirrors
+// how `az account get-access-token` is used to bootstrap automation against
+// ARO without juggling client secrets.
+func GenerateFederatedCredentials(resourceGroup string) (*FederatedToken, error) {
+	var stdout bytes.Buffer
+
+	cmd := exec.Command("az", "account", "get-access-token", "--resource", azureResourceManagerResource, "--output", "json")
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("unable to obtain az access token for resource group %s: %s", resourceGroup, err)
+	}
+
+	var token FederatedToken
+	if err := json.Unmarshal(stdout.Bytes(), &token); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal az token output: %s", err)
+	}
+
+	return &token, nil
+}
+
+// NewFederatedClient returns a Client carrying a federated access token for
+// the given resource group.
+func NewFederatedClient(resourceGroup string) (*Client, error) {
+	token, err := GenerateFederatedCredentials(resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		ResourceGroup: resourceGroup,
+		AccessToken:   token.AccessToken,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	credentials "cloud.google.com/go/iam/credentials/apiv1"
+	"cloud.google.com/go/iam/credentials/apiv1/credentialspb"
+)
+
+// Client wraps a GCP credential obtained by impersonating a support service
+// account, analogous to aws.Client for AWS-backed clusters.
+type Client struct {
+	ServiceAccount string
+	AccessToken    string
+}
+
+// GenerateSupportServiceAccountEmail derives the support service account
+// email osdctl should impersonate for the given cluster.
+func GenerateSupportServiceAccountEmail(clusterID string) (string, error) {
+	if clusterID == "" {
+		return "", fmt.Errorf("clusterID is required")
+	}
+
+	return fmt.Sprintf("osd-support-%s@gcp-support.iam.gserviceaccount.com", clusterID), nil
+}
+
+// GenerateImpersonatedCredentials exchanges the caller's ambient credentials
+// for a short-lived access token scoped to the given service account via the
+// IAM Credentials API's generateAccessToken method. The caller's ambient
+// credentials are resolved the normal way (Application Default Credentials -
+// gcloud auth application-default login, GOOGLE_APPLICATION_CREDENTIALS, or
+// the workload's attached service account), and must already have
+// roles/iam.serviceAccountTokenCreator on serviceAccount.
+func GenerateImpersonatedCredentials(serviceAccount string) (*credentialspb.GenerateAccessTokenResponse, error) {
+	ctx := context.Background()
+
+	client, err := credentials.NewIamCredentialsClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create IAM credentials client: %s", err)
+	}
+	defer client.Close()
+
+	resp, err := client.GenerateAccessToken(ctx, &credentialspb.GenerateAccessTokenRequest{
+		Name:  fmt.Sprintf("projects/-/serviceAccounts/%s", serviceAccount),
+		Scope: []string{"https://www.googleapis.com/auth/cloud-platform"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to impersonate service account %s: %s", serviceAccount, err)
+	}
+
+	return resp, nil
+}
+
+// NewImpersonatedClient returns a Client carrying a short-lived access token
+// for the given service account.
+func NewImpersonatedClient(serviceAccount string) (*Client, error) {
+	token, err := GenerateImpersonatedCredentials(serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		ServiceAccount: serviceAccount,
+		AccessToken:    token.AccessToken,
+	}, nil
+}